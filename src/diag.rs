@@ -87,12 +87,160 @@ pub fn render(diags: &[Diagnostic], file: &str, source: &str) -> String {
         out.push_str(&format!("error[{}]: {}\n", d.kind, d.message));
         out.push_str(&format!("  --> {}:{}:{}\n", file, d.span.line, d.span.col));
         if d.span.line >= 1 && d.span.line <= lines.len() {
-            let src_line = lines[d.span.line - 1];
+            let (shown, offset) = display_line(lines[d.span.line - 1], d.span.col);
             let num = format!("{:>4}", d.span.line);
-            out.push_str(&format!("{} | {}\n", num, src_line));
-            let pad = " ".repeat(num.len() + 3 + d.span.col.saturating_sub(1));
+            out.push_str(&format!("{} | {}\n", num, shown));
+            let pad = " ".repeat(num.len() + 3 + offset);
             out.push_str(&format!("{}^\n", pad));
         }
     }
     out
 }
+
+/// Tabs show as spaces out to the next multiple of this. The grammar rejects
+/// tabs, but the line carrying that rejection still needs a caret that lands.
+const TAB_STOP: usize = 4;
+
+/// A source line as the terminal draws it, and the cell where the 1-based
+/// char column `col` starts. Spans count chars; a terminal counts cells, so
+/// a tab or a wide character ahead of the caret would otherwise shift it.
+fn display_line(line: &str, col: usize) -> (String, usize) {
+    let mut shown = String::new();
+    let mut cells = 0;
+    let mut offset = None;
+    for (i, c) in line.chars().enumerate() {
+        if i + 1 == col {
+            offset = Some(cells);
+        }
+        match c {
+            '\t' => {
+                let gap = TAB_STOP - cells % TAB_STOP;
+                shown.push_str(&" ".repeat(gap));
+                cells += gap;
+            }
+            _ => {
+                shown.push(c);
+                cells += char_cells(c);
+            }
+        }
+    }
+    // a span past the last char (end of line, missing newline) sits that
+    // many cells beyond it
+    let past_end = col.saturating_sub(line.chars().count() + 1);
+    (shown, offset.unwrap_or(cells + past_end))
+}
+
+/// Terminal cells for one char: two for East Asian wide and emoji code
+/// points, none for combining marks and joiners, one otherwise. Ranges
+/// follow Unicode's East Asian Width table closely enough for carets.
+fn char_cells(c: char) -> usize {
+    match c as u32 {
+        0x0300..=0x036F | 0x200B..=0x200F | 0xFE00..=0xFE0F => 0,
+        0x1100..=0x115F
+        | 0x2E80..=0x303E
+        | 0x3041..=0x33FF
+        | 0x3400..=0x4DBF
+        | 0x4E00..=0x9FFF
+        | 0xA000..=0xA4CF
+        | 0xAC00..=0xD7A3
+        | 0xF900..=0xFAFF
+        | 0xFE30..=0xFE4F
+        | 0xFF00..=0xFF60
+        | 0xFFE0..=0xFFE6
+        | 0x1F300..=0x1F64F
+        | 0x1F680..=0x1F6FF
+        | 0x1F900..=0x1F9FF
+        | 0x1FA70..=0x1FAFF
+        | 0x20000..=0x3FFFD => 2,
+        _ => 1,
+    }
+}
+
+/// Rendered diagnostics as a JSON array, for CI and editors that read records
+/// rather than carets: one object per `error[kind]` or `advisory[kind]`
+/// block, located when the block names a place. A bare `error: ...` line (an
+/// unresolved import, a library with nothing to run) has no kind. A place
+/// may be a file alone (`  --> file`, or a module's `(module dir)` suffix);
+/// line and col appear only when both are numbers.
+pub fn json(rendered: &str) -> String {
+    struct Record<'a> {
+        severity: &'a str,
+        kind: Option<&'a str>,
+        file: Option<&'a str>,
+        at: Option<(usize, usize)>,
+        message: &'a str,
+    }
+    let mut records: Vec<Record> = Vec::new();
+    for line in rendered.lines() {
+        if let Some(place) = line.strip_prefix("  --> ") {
+            let Some(record) = records.last_mut() else { continue };
+            let mut parts = place.rsplitn(3, ':');
+            let (col, number, file) = (parts.next(), parts.next(), parts.next());
+            let number = number.and_then(|n| n.parse::<usize>().ok());
+            let col = col.and_then(|c| c.parse::<usize>().ok());
+            match (file, number.zip(col)) {
+                (Some(file), Some(at)) => {
+                    record.file = Some(file);
+                    record.at = Some(at);
+                }
+                _ => record.file = Some(place),
+            }
+            continue;
+        }
+        let (severity, kind, message) = if let Some(message) = line.strip_prefix("error: ") {
+            ("error", None, message)
+        } else if let Some((head, message)) = line.split_once("]: ") {
+            match head.split_once('[') {
+                Some((severity @ ("error" | "advisory"), kind)) => (severity, Some(kind), message),
+                _ => continue,
+            }
+        } else {
+            continue;
+        };
+        // module-level checks name the directory in the message, not on a
+        // `  --> ` line
+        let file = message
+            .strip_suffix(')')
+            .and_then(|m| m.rsplit_once(" (module "))
+            .map(|(_, dir)| dir);
+        records.push(Record { severity, kind, file, at: None, message });
+    }
+    let objects: Vec<String> = records
+        .iter()
+        .map(|r| {
+            let mut fields = vec![format!("\"severity\":{}", quote(r.severity))];
+            if let Some(kind) = r.kind {
+                fields.push(format!("\"kind\":{}", quote(kind)));
+            }
+            if let Some(file) = r.file {
+                fields.push(format!("\"file\":{}", quote(file)));
+            }
+            if let Some((number, col)) = r.at {
+                fields.push(format!("\"line\":{number}"));
+                fields.push(format!("\"col\":{col}"));
+            }
+            fields.push(format!("\"message\":{}", quote(r.message)));
+            format!("  {{{}}}", fields.join(","))
+        })
+        .collect();
+    match objects.is_empty() {
+        true => "[]\n".to_string(),
+        false => format!("[\n{}\n]\n", objects.join(",\n")),
+    }
+}
+
+fn quote(text: &str) -> String {
+    let mut out = String::from("\"");
+    for c in text.chars() {
+        match c {
+            '"' => out.push_str("\\\""),
+            '\\' => out.push_str("\\\\"),
+            '\n' => out.push_str("\\n"),
+            '\t' => out.push_str("\\t"),
+            c if (c as u32) < 0x20 => out.push_str(&format!("\\u{:04x}", c as u32)),
+            c => out.push(c),
+        }
+    }
+    out.push('"');
+    out
+}
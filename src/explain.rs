@@ -0,0 +1,237 @@
+//! `kanso explain <kind>`: the longer account of a diagnostic kind, the word
+//! between the brackets of `error[kind]` or `advisory[kind]`. The kinds are
+//! the stable codes — the corpora pin them and `check --json` reports them —
+//! so an explanation is looked up by the word the user just read.
+
+/// Every kind the compiler and the runtime report, advisories included,
+/// alphabetical. A new kind lands here in the change that first emits it;
+/// tests/explain.rs holds the error and advisory corpora to that.
+pub const KINDS: [(&str, &str); 14] = [
+    ("arity", ARITY),
+    ("build", BUILD),
+    ("dispatch", DISPATCH),
+    ("door", DOOR),
+    ("endpoint", ENDPOINT),
+    ("formatting", FORMATTING),
+    ("name", NAME),
+    ("naming", NAMING),
+    ("opacity", OPACITY),
+    ("ownership", OWNERSHIP),
+    ("runtime", RUNTIME),
+    ("signature", SIGNATURE),
+    ("syntax", SYNTAX),
+    ("unused", UNUSED),
+];
+
+pub fn explain(kind: &str) -> Option<&'static str> {
+    KINDS.iter().find(|(k, _)| *k == kind).map(|(_, text)| *text)
+}
+
+const ARITY: &str = "\
+error[arity]: a call passes a number of arguments no arm accepts
+
+Application is juxtaposition, so `f a b` is a two-argument call. Each arm
+of a function fixes how many arguments it takes, and a call has to match
+one of those counts.
+
+    fn double n
+      n * 2
+
+    pub play = print \"{double 1 2}\"
+
+`double` has only a one-argument arm. Pass one argument, or add an arm
+that takes two.
+";
+
+const BUILD: &str = "\
+error[build]: `set` writes a value the build block did not make
+
+`build` is the one place mutation parses. Inside it, `set target field
+value` writes a field of a record constructed in that same block. A value
+that arrived from outside was already visible elsewhere, so it stays
+immutable.
+
+    fn mutate n
+      build
+        a = node 9 []
+        set n peers [a]
+        a
+
+`n` is a parameter. Construct the record inside the block and set fields
+on that one.
+";
+
+const DISPATCH: &str = "\
+error[dispatch]: two arms of a function could claim the same call
+
+Overloads dispatch most-specific first: literals, then concrete types,
+then generics. Every call must have exactly one best arm, so two arms
+with the same specificity and the same shape are rejected, as are arms
+that each win somewhere and tie on some call. A constant takes no
+parameters and so admits no overloads at all.
+
+    fn describe n
+      \"{n}\"
+
+    fn describe x
+      \"{x}\"
+
+Both arms accept anything. Delete one, or make one more specific with a
+literal or a type annotation.
+";
+
+const DOOR: &str = "\
+advisory[door]: a pub fn hands callers a foreign type they cannot use
+
+`kanso check` advises where it does not reject. A pub fn that returns a
+record type from another module gives its callers a value they can hold
+but not open, because the type's structure stays with its own module.
+Unless the surface also offers an operation that accepts that type,
+callers get a handle with nothing to pass it to.
+
+    import \"std/json\"
+
+    pub fn parse text
+      json/decode text
+
+`parse` can return a `json/parse_failure`. Re-export an operation that
+takes it, or wrap it in a type of your own and give callers arms for
+that.
+";
+
+const ENDPOINT: &str = "\
+error[endpoint]: an err or none reached the program's edge unhandled
+
+Failure is a value. Division by zero, a strict index miss, or an explicit
+`err reason` produces an `err` that flows through every function it
+reaches until an arm that matches on it handles it. If it arrives at
+`main` or the executor still unhandled, the program stops and reports
+where it was born and the calls it passed through.
+
+    print \"{1 / 0}\"
+
+Add an arm that matches the failure, such as `fn describe (err reason)`,
+or use `at` where absence is expected and handle the `none`.
+";
+
+const FORMATTING: &str = "\
+error[formatting]: the source is not in canonical form
+
+A program has one rendering. Indentation, spacing, blank lines, line
+width, and the order of declarations, fields, and imports are all part
+of the grammar, so there is no formatter to run: the compiler names the
+first place the text departs from the canonical form.
+
+    total = sum [1, 2]
+
+kanso has no commas. Write `sum [1 2]`. The message names the rule; fix
+the line it points at.
+";
+
+const NAME: &str = "\
+error[name]: a name is unknown, taken, or out of reach
+
+Every name resolves to one meaning. A use must refer to a binding, a
+declaration, a builtin, or an imported pub. A binding may not reuse the
+name of a declared function, type, or builtin, and a top-level name is
+declared once.
+
+    print \"{greeting}\"
+
+Nothing is named `greeting`. Bind it, declare it, or import the module
+that provides it.
+";
+
+const NAMING: &str = "\
+error[naming]: a name does not say what the function answers
+
+A function that only ever returns true or false is a question, and its
+name ends in `?`. A name ending in `?` must answer only true or false.
+Privacy is the absence of `pub`, so leading underscores are not used to
+mark it.
+
+    fn small n
+      n < 10
+
+Rename it `small?`.
+";
+
+const OPACITY: &str = "\
+error[opacity]: the structure of a foreign type was used across an import
+
+A record type belongs to the module that declares it. Other modules may
+hold its values and pass them to its pub operations, but may not
+construct or destructure it, and may not reach its private names.
+
+    import \"std/json\"
+
+    json/parse_failure p reason = json/parse_failure 3 \"boom\"
+
+Use the operations the json module makes pub instead of the record's
+fields.
+";
+
+const OWNERSHIP: &str = "\
+error[ownership]: an arm extends rendering for a type this module does not own
+
+`to_string` decides how a value prints inside `\"{...}\"`. A module may add
+an arm only for a type it declares. Rendering of primitives and of the
+`none` and `err` sentinels is fixed.
+
+    fn to_string n:int
+      \"number\"
+
+Wrap the value in a type of your own and give that type the arm.
+";
+
+const RUNTIME: &str = "\
+error[runtime]: evaluation reached a state the program cannot continue from
+
+These are defects, and no arm can handle them: no overload matched the
+arguments of a call, a builtin received a value of the wrong shape, or a
+native int overflowed. The message names the call. Check that every value
+reaching the function has an arm that matches it.
+";
+
+const SIGNATURE: &str = "\
+error[signature]: a declaration is used with the wrong shape
+
+A marker type has no fields, so its bare mention is its value and it
+takes no arguments. `main` is the program's description and takes no
+parameters.
+
+    type null
+
+    pub play =
+      bad = null true
+      print \"{bad}\"
+
+Write `null` on its own.
+";
+
+const SYNTAX: &str = "\
+error[syntax]: the text does not parse
+
+The statement does not fit the grammar at the marked position. The
+message names what the parser expected or why the construct is not
+allowed there.
+
+    user _ name = user false \"clay\"
+
+`_` does not appear in binding patterns. Read only the fields you need
+with a keyed read: `{ name } = user false \"clay\"`.
+";
+
+const UNUSED: &str = "\
+error[unused]: something the program computes is never used
+
+Every binding is read, every private declaration is used in its module,
+and every import is used. A line in a body either binds a name or is an
+effect, and only the last line is the result. Rebinding a name before
+its previous version was used is also an unused binding.
+
+    x = 1
+    print \"hi\"
+
+Delete the binding, or use it.
+";
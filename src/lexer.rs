@@ -69,7 +69,9 @@ pub fn lex(source: &str) -> Result<Lexed, Vec<Diagnostic>> {
     }
     for (idx, raw) in source.lines().enumerate() {
         let number = idx + 1;
-        if let Some(col) = raw.find('\t') {
+        // spans count chars, never bytes: a multi-byte char ahead of the
+        // finding must not push its column right
+        if let Some(col) = raw.chars().position(|c| c == '\t') {
             diags.push(Diagnostic::new(
                 "formatting",
                 "tabs are not part of the canonical grammar; indent with spaces".to_string(),
@@ -81,7 +83,7 @@ pub fn lex(source: &str) -> Result<Lexed, Vec<Diagnostic>> {
             diags.push(Diagnostic::new(
                 "formatting",
                 "trailing whitespace is not part of the canonical grammar".to_string(),
-                Span { line: number, col: raw.trim_end().len() + 1 },
+                Span { line: number, col: raw.trim_end().chars().count() + 1 },
             ));
         }
         let trimmed = raw.trim_end();
@@ -8,6 +8,7 @@ pub mod diag;
 pub mod dispatch;
 pub mod escape;
 pub mod eval;
+pub mod explain;
 pub mod infer;
 pub mod lexer;
 pub mod linear;
@@ -6,12 +6,16 @@ fn main() -> ExitCode {
     if args.first().map(String::as_str) == Some("repl") {
         return repl();
     }
-    let (command, file, plan, release, interp) = match parse_args(&args) {
+    if args.first().map(String::as_str) == Some("explain") {
+        return explain(args.get(1).map(String::as_str));
+    }
+    let (command, file, plan, release, interp, json) = match parse_args(&args) {
         Some(parsed) => parsed,
         None => {
             eprintln!(
-                "usage: kanso run <file.kso> [--plan|--interp] | kanso check <file.kso> | kanso \
-                 test <file.kso> | kanso build <file.kso> [--release] | kanso repl"
+                "usage: kanso run <file.kso> [--plan|--interp] | kanso check <file.kso> [--json] \
+                 | kanso test <file.kso> | kanso build <file.kso> [--release] | kanso repl \
+                 | kanso explain <kind>"
             );
             return ExitCode::from(2);
         }
@@ -21,25 +25,19 @@ fn main() -> ExitCode {
     let (program, source) = match path.is_dir() {
         true => match kanso::compile_module(path, require_main) {
             Ok(program) => (program, String::new()),
-            Err(rendered) => {
-                eprint!("{}", diag::paint(&rendered));
-                return ExitCode::from(2);
-            }
+            Err(rendered) => return compile_failed(&rendered, json),
         },
         false => {
             let source = match std::fs::read_to_string(&file) {
                 Ok(source) => source,
                 Err(io) => {
-                    eprintln!("error: cannot read {file}: {io}");
-                    return ExitCode::from(2);
+                    let msg = format!("error: cannot read {file}: {io}\n");
+                    return compile_failed(&msg, json);
                 }
             };
             match kanso::compile_source(&command, &file, &source) {
                 Ok(program) => (program, source),
-                Err(rendered) => {
-                    eprint!("{}", diag::paint(&rendered));
-                    return ExitCode::from(2);
-                }
+                Err(rendered) => return compile_failed(&rendered, json),
             }
         }
     };
@@ -50,7 +48,12 @@ fn main() -> ExitCode {
         }
     }
     if command == "check" {
-        for advisory in kanso::advisory::door_advisories(&program) {
+        let advisories = kanso::advisory::door_advisories(&program);
+        if json {
+            print!("{}", diag::json(&advisories.join("\n")));
+            return ExitCode::SUCCESS;
+        }
+        for advisory in advisories {
             eprintln!("{advisory}");
         }
         println!("{file}: ok");
@@ -68,7 +71,7 @@ fn main() -> ExitCode {
     run(&program, &file, &source, plan)
 }
 
-fn parse_args(args: &[String]) -> Option<(String, String, bool, bool, bool)> {
+fn parse_args(args: &[String]) -> Option<(String, String, bool, bool, bool, bool)> {
     let command = args.first()?.clone();
     if command != "run" && command != "check" && command != "test" && command != "build" && command != "play" {
         return None;
@@ -78,11 +81,13 @@ fn parse_args(args: &[String]) -> Option<(String, String, bool, bool, bool)> {
     let mut plan = false;
     let mut release = false;
     let mut interp = false;
+    let mut json = false;
     for arg in rest.by_ref() {
         match arg.as_str() {
             "--plan" => plan = true,
             "--release" => release = true,
             "--interp" => interp = true,
+            "--json" => json = true,
             // worst-case measurement: thunk nothing, force everything. The
             // env var carries it to every stage (demand runs in infer,
             // codegen, and the interp) and into the spawned native binary.
@@ -97,7 +102,36 @@ fn parse_args(args: &[String]) -> Option<(String, String, bool, bool, bool)> {
     if release && command != "build" {
         return None;
     }
-    Some((command, file, plan, release, interp))
+    if json && command != "check" {
+        return None;
+    }
+    Some((command, file, plan, release, interp, json))
+}
+
+/// `kanso explain name`: the longer account of `error[name]` or
+/// `advisory[name]`.
+fn explain(kind: Option<&str>) -> ExitCode {
+    match kind.and_then(kanso::explain::explain) {
+        Some(text) => {
+            print!("{text}");
+            ExitCode::SUCCESS
+        }
+        None => {
+            let kinds: Vec<&str> = kanso::explain::KINDS.iter().map(|(k, _)| *k).collect();
+            eprintln!("usage: kanso explain <kind>, one of: {}", kinds.join(" "));
+            ExitCode::from(2)
+        }
+    }
+}
+
+/// A compile that stopped on diagnostics: carets on stderr, or under
+/// `check --json` the same diagnostics as records on stdout.
+fn compile_failed(rendered: &str, json: bool) -> ExitCode {
+    match json {
+        true => print!("{}", diag::json(rendered)),
+        false => eprint!("{}", diag::paint(rendered)),
+    }
+    ExitCode::from(2)
 }
 
 /// Execute `main` on the reference interpreter — the semantics oracle. `run`
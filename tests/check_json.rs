@@ -0,0 +1,139 @@
+use std::path::PathBuf;
+use std::process::Command;
+
+fn manifest_dir() -> PathBuf {
+    PathBuf::from(env!("CARGO_MANIFEST_DIR"))
+}
+
+#[test]
+fn check_json_reports_diagnostics_as_records_on_stdout() {
+    let output = Command::new(env!("CARGO_BIN_EXE_kanso"))
+        .args(["check", "unknown_name.kso", "--json"])
+        .current_dir(manifest_dir().join("tests/golden/errors"))
+        .output()
+        .expect("kanso binary runs");
+
+    assert_eq!(output.status.code(), Some(2), "compile errors exit 2");
+    assert!(output.stderr.is_empty(), "json mode keeps stderr clear");
+    assert_eq!(
+        String::from_utf8_lossy(&output.stdout),
+        "[\n  {\"severity\":\"error\",\"kind\":\"name\",\"file\":\"unknown_name.kso\",\
+         \"line\":1,\"col\":9,\"message\":\"unknown name `mystery`\"}\n]\n"
+    );
+}
+
+#[test]
+fn check_json_prints_an_empty_array_for_a_clean_file() {
+    let output = Command::new(env!("CARGO_BIN_EXE_kanso"))
+        .args(["check", "hello.kso", "--json"])
+        .current_dir(manifest_dir().join("examples"))
+        .output()
+        .expect("kanso binary runs");
+
+    assert_eq!(
+        output.status.code(),
+        Some(0),
+        "stderr: {}",
+        String::from_utf8_lossy(&output.stderr)
+    );
+    assert_eq!(String::from_utf8_lossy(&output.stdout), "[]\n");
+}
+
+#[test]
+fn check_json_reports_an_unreadable_file_as_a_record() {
+    let output = Command::new(env!("CARGO_BIN_EXE_kanso"))
+        .args(["check", "missing.kso", "--json"])
+        .current_dir(manifest_dir().join("tests/golden/errors"))
+        .output()
+        .expect("kanso binary runs");
+
+    assert_eq!(output.status.code(), Some(2));
+    assert!(output.stderr.is_empty(), "json mode keeps stderr clear");
+    let stdout = String::from_utf8_lossy(&output.stdout);
+    assert!(
+        stdout.starts_with("[\n  {\"severity\":\"error\",\"message\":\"cannot read missing.kso: "),
+        "stdout: {stdout}"
+    );
+    assert!(stdout.ends_with("\"}\n]\n"), "stdout: {stdout}");
+}
+
+#[test]
+fn check_json_reports_an_advisory_without_a_location() {
+    let output = Command::new(env!("CARGO_BIN_EXE_kanso"))
+        .args(["check", "tests/golden/advisory/leaky", "--json"])
+        .current_dir(manifest_dir())
+        .output()
+        .expect("kanso binary runs");
+
+    assert_eq!(
+        output.status.code(),
+        Some(0),
+        "advisories do not fail the check"
+    );
+    assert!(output.stderr.is_empty(), "json mode keeps stderr clear");
+    assert_eq!(
+        String::from_utf8_lossy(&output.stdout),
+        "[\n  {\"severity\":\"advisory\",\"kind\":\"door\",\"message\":\"`parse` returns \
+         `json/parse_failure` and the surface offers nothing that accepts it — re-export \
+         what callers need, or wrap it\"}\n]\n"
+    );
+}
+
+#[test]
+fn check_json_records_a_file_without_line_and_col() {
+    // mentions `pub play` but declares `pub player`: the nothing-to-play
+    // error names the file alone
+    let output = Command::new(env!("CARGO_BIN_EXE_kanso"))
+        .args(["check", "player.kso", "--json"])
+        .current_dir(manifest_dir().join("tests/golden/check_json"))
+        .output()
+        .expect("kanso binary runs");
+
+    assert_eq!(output.status.code(), Some(2));
+    assert!(output.stderr.is_empty(), "json mode keeps stderr clear");
+    assert_eq!(
+        String::from_utf8_lossy(&output.stdout),
+        "[\n  {\"severity\":\"error\",\"file\":\"player.kso\",\"message\":\"nothing to play — \
+         define `pub play`, or point `kanso run` at an entry file\"}\n]\n"
+    );
+}
+
+#[test]
+fn check_json_reports_every_diagnostic_of_a_file() {
+    let output = Command::new(env!("CARGO_BIN_EXE_kanso"))
+        .args(["check", "build_set_outside.kso", "--json"])
+        .current_dir(manifest_dir().join("tests/golden/errors"))
+        .output()
+        .expect("kanso binary runs");
+
+    assert_eq!(output.status.code(), Some(2));
+    assert_eq!(
+        String::from_utf8_lossy(&output.stdout),
+        "[\n  {\"severity\":\"error\",\"kind\":\"name\",\"file\":\"build_set_outside.kso\",\
+         \"line\":7,\"col\":3,\"message\":\"`set` lives inside `build` — mutation does not \
+         parse anywhere else\"},\n  {\"severity\":\"error\",\"kind\":\"name\",\
+         \"file\":\"build_set_outside.kso\",\"line\":7,\"col\":9,\
+         \"message\":\"unknown name `peers`\"}\n]\n"
+    );
+}
+
+#[test]
+fn json_keeps_a_place_whole_when_it_carries_no_line_and_col() {
+    let rendered = "error: nothing to play\n  --> a:b:c.kso\n";
+
+    assert_eq!(
+        kanso::diag::json(rendered),
+        "[\n  {\"severity\":\"error\",\"file\":\"a:b:c.kso\",\"message\":\"nothing to play\"}\n]\n"
+    );
+}
+
+#[test]
+fn json_takes_a_module_failure_file_from_its_suffix() {
+    let rendered = "error[unused]: private `f` is never used in its module (module lib/json)\n";
+
+    assert_eq!(
+        kanso::diag::json(rendered),
+        "[\n  {\"severity\":\"error\",\"kind\":\"unused\",\"file\":\"lib/json\",\
+         \"message\":\"private `f` is never used in its module (module lib/json)\"}\n]\n"
+    );
+}
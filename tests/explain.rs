@@ -0,0 +1,84 @@
+use std::path::PathBuf;
+use std::process::Command;
+
+fn manifest_dir() -> PathBuf {
+    PathBuf::from(env!("CARGO_MANIFEST_DIR"))
+}
+
+/// Every `severity[kind]` a golden pins: compile-time and runtime errors
+/// from their .stderr files, and the advisories `check` gives the advisory
+/// modules.
+fn golden_kinds() -> Vec<(String, String)> {
+    let mut heads = Vec::new();
+    for dir in ["tests/golden/errors", "tests/golden/runtime"] {
+        for entry in std::fs::read_dir(manifest_dir().join(dir)).expect("golden directory") {
+            let path = entry.expect("directory entry").path();
+            if path.extension().is_none_or(|ext| ext != "stderr") {
+                continue;
+            }
+            let text = std::fs::read_to_string(&path).expect("golden reads");
+            heads.extend(text.lines().map(str::to_string));
+        }
+    }
+    let advisory = manifest_dir().join("tests/golden/advisory");
+    for entry in std::fs::read_dir(&advisory).expect("advisory directory") {
+        let path = entry.expect("directory entry").path();
+        let program =
+            kanso::compile_module(&path, false).unwrap_or_else(|_| panic!("{path:?} compiles"));
+        heads.extend(kanso::advisory::door_advisories(&program));
+    }
+    let mut kinds = Vec::new();
+    for line in heads {
+        let Some((severity, rest)) = line.split_once('[') else { continue };
+        if severity != "error" && severity != "advisory" {
+            continue;
+        }
+        let Some(close) = rest.find("]: ") else { continue };
+        kinds.push((severity.to_string(), rest[..close].to_string()));
+    }
+    kinds.sort();
+    kinds.dedup();
+    kinds
+}
+
+#[test]
+fn every_kind_in_the_goldens_has_an_explanation() {
+    let kinds = golden_kinds();
+    assert!(
+        kinds.iter().any(|(severity, _)| severity == "advisory"),
+        "the advisory corpus yields at least one kind"
+    );
+    for (severity, kind) in kinds {
+        let text = kanso::explain::explain(&kind)
+            .unwrap_or_else(|| panic!("no explanation for {severity}[{kind}]"));
+        assert!(
+            text.starts_with(&format!("{severity}[{kind}]: ")),
+            "explanation of {kind} opens with its own header"
+        );
+    }
+}
+
+#[test]
+fn explain_prints_the_account_of_a_kind() {
+    let output = Command::new(env!("CARGO_BIN_EXE_kanso"))
+        .args(["explain", "unused"])
+        .output()
+        .expect("kanso binary runs");
+
+    assert_eq!(output.status.code(), Some(0));
+    assert_eq!(
+        String::from_utf8_lossy(&output.stdout),
+        kanso::explain::explain("unused").expect("unused is a kind")
+    );
+}
+
+#[test]
+fn explain_lists_the_kinds_for_an_unknown_one() {
+    let output = Command::new(env!("CARGO_BIN_EXE_kanso"))
+        .args(["explain", "mystery"])
+        .output()
+        .expect("kanso binary runs");
+
+    assert_eq!(output.status.code(), Some(2));
+    assert!(String::from_utf8_lossy(&output.stderr).contains("one of: arity build dispatch"));
+}